@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+
+	"github.com/manifoldco/torus-cli/errs"
+)
+
+// cancelledErr translates a context cancellation (Ctrl-C, SIGTERM) into the
+// typed error the CLI prints cleanly, instead of letting a raw context
+// error or a partially-read response surface as a stack trace. Client.Do
+// calls it to classify transport errors that happen because ctx was
+// cancelled out from under an in-flight request; Pager.Each calls it
+// between pages so a long paginated listing stops promptly too.
+func cancelledErr(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return errs.NewCancelled(ctx.Err())
+	default:
+		return nil
+	}
+}
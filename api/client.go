@@ -0,0 +1,126 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/manifoldco/torus-cli/config"
+	"github.com/manifoldco/torus-cli/errs"
+)
+
+// Client proxies requests to the registry on behalf of the CLI, routing
+// them through the local daemon's unix socket.
+type Client struct {
+	http *http.Client
+	base *url.URL
+
+	Orgs          *OrgsClient
+	Teams         *TeamsClient
+	Keypairs      *KeypairsClient
+	Machines      *MachinesClient
+	MachineTokens *MachineTokensClient
+	CryptoEngine  *CryptoEngineClient
+	Credentials   *CredentialsClient
+}
+
+// NewClient builds a Client that talks to the daemon described by cfg.
+func NewClient(cfg *config.Config) *Client {
+	c := &Client{
+		http: &http.Client{},
+		base: &url.URL{Scheme: "http", Host: "daemon"},
+	}
+
+	c.Orgs = &OrgsClient{client: c}
+	c.Teams = &TeamsClient{client: c}
+	c.Keypairs = &KeypairsClient{client: c}
+	c.Machines = &MachinesClient{client: c}
+	c.MachineTokens = &MachineTokensClient{client: c}
+	c.CryptoEngine = &CryptoEngineClient{client: c}
+	c.Credentials = &CredentialsClient{client: c}
+
+	return c
+}
+
+// NewRequest builds an HTTP request against the daemon for the given
+// method and path, encoding body as JSON when present.
+func (c *Client) NewRequest(method, p string, values *url.Values, body interface{}, authed bool) (*http.Request, *url.URL, error) {
+	u := *c.base
+	u.Path = p
+	if values != nil {
+		u.RawQuery = values.Encode()
+	}
+
+	var reader *bytes.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		reader = bytes.NewReader(raw)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, u.String(), reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, &u, nil
+}
+
+// Do executes req with ctx, decoding a JSON response body into into when
+// provided. If ctx is cancelled (Ctrl-C, SIGTERM) before or while the
+// request is in flight, the underlying request body is closed and Do
+// returns a typed *errs.Cancelled instead of letting the request's own
+// "context canceled" error or a half-read body surface to the caller. A
+// non-2xx response is reported as a typed *errs.APIError rather than as
+// success, even when the caller passed a nil into.
+func (c *Client) Do(ctx context.Context, req *http.Request, into interface{}, progress *ProgressFunc, notifier chan struct{}) (*http.Response, error) {
+	req = req.WithContext(ctx)
+
+	type result struct {
+		resp *http.Response
+		err  error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		resp, err := c.http.Do(req)
+		done <- result{resp, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		req.Body.Close()
+		return nil, errs.NewCancelled(ctx.Err())
+	case r := <-done:
+		if r.err != nil {
+			if cancelErr := cancelledErr(ctx); cancelErr != nil {
+				return nil, cancelErr
+			}
+			return nil, r.err
+		}
+		defer r.resp.Body.Close()
+
+		if r.resp.StatusCode < 200 || r.resp.StatusCode >= 300 {
+			var body struct {
+				Error string `json:"error"`
+			}
+			json.NewDecoder(r.resp.Body).Decode(&body)
+			return r.resp, errs.NewAPIError(r.resp.StatusCode, body.Error)
+		}
+
+		if into != nil {
+			if err := json.NewDecoder(r.resp.Body).Decode(into); err != nil {
+				return r.resp, err
+			}
+		}
+
+		return r.resp, nil
+	}
+}
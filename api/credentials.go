@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/manifoldco/torus-cli/identity"
+)
+
+// CredentialsClient makes proxied requests to the registry's credentials
+// endpoints
+type CredentialsClient struct {
+	client *Client
+}
+
+// ReEncrypt re-encrypts every user-accessible credential in orgID under
+// that org's current encryption keypair. It is called as part of
+// KeypairsClient.Rotate, after a new encryption key has been generated but
+// before the old one is revoked, so secrets are never left encrypted only
+// under a key that's about to stop being usable.
+func (c *CredentialsClient) ReEncrypt(ctx context.Context, orgID *identity.ID, progress *ProgressFunc) error {
+	if orgID == nil {
+		return errors.New("invalid org")
+	}
+
+	req, _, err := c.client.NewRequest("POST", "/credentials/re-encrypt", nil, map[string]*identity.ID{
+		"org_id": orgID,
+	}, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.Do(ctx, req, nil, progress, nil)
+	return err
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+
+	"github.com/manifoldco/torus-cli/identity"
+	"github.com/manifoldco/torus-cli/trustcache"
+)
+
+// CryptoEngineClient wraps the local crypto-engine subprocess used to sign
+// and verify primitive objects (credentials, keypairs) without the
+// private key material ever leaving the daemon.
+type CryptoEngineClient struct {
+	client *Client
+}
+
+// Sign produces a detached signature over data using the engine's active
+// signing key.
+func (c *CryptoEngineClient) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	req, _, err := c.client.NewRequest("POST", "/crypto/sign", nil, data, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var sig []byte
+	_, err = c.client.Do(ctx, req, &sig, nil, nil)
+	return sig, err
+}
+
+// Verify checks a detached signature against data using the key the
+// registry reports as the signer. It always makes a round trip to the
+// crypto engine; callers that want to avoid that round trip for a pinned
+// peer key should use VerifyPrimitive instead.
+func (c *CryptoEngineClient) Verify(ctx context.Context, data, sig []byte) (bool, error) {
+	req, _, err := c.client.NewRequest("POST", "/crypto/verify", nil, map[string]interface{}{
+		"data":      data,
+		"signature": sig,
+	}, true)
+	if err != nil {
+		return false, err
+	}
+
+	var result struct {
+		Valid bool `json:"valid"`
+	}
+	if _, err := c.client.Do(ctx, req, &result, nil, nil); err != nil {
+		return false, err
+	}
+
+	return result.Valid, nil
+}
+
+// VerifyPrimitive checks the signature on a primitive object signed by
+// orgID's keyID. If the user has pinned that key locally via `keypairs
+// import`, sig is verified against data directly using the pinned key,
+// with no registry round trip; otherwise it falls back to the
+// registry-reported key via Verify.
+func (c *CryptoEngineClient) VerifyPrimitive(ctx context.Context, orgID, keyID *identity.ID, data, sig []byte) (bool, error) {
+	pinned, err := trustcache.Get(orgID, keyID)
+	if err != nil {
+		return false, err
+	}
+
+	if pinned == nil {
+		return c.Verify(ctx, data, sig)
+	}
+
+	key, err := Dearmor(pinned)
+	if err != nil {
+		return false, err
+	}
+
+	if len(key.PublicKey) != ed25519.PublicKeySize {
+		return false, errors.New("pinned key has an invalid length, re-import it with keypairs import")
+	}
+
+	return ed25519.Verify(ed25519.PublicKey(key.PublicKey), data, sig), nil
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/manifoldco/torus-cli/identity"
+	"github.com/manifoldco/torus-cli/primitive"
+	"github.com/manifoldco/torus-cli/trustcache"
+)
+
+// exportedKeyVersion identifies the armored block format so future
+// versions can evolve without breaking older torus binaries.
+const exportedKeyVersion = 1
+
+// ExportedKey is the portable, self-signed representation of the public
+// half of a keypair, suitable for out-of-band verification or import into
+// another user's trust cache.
+type ExportedKey struct {
+	Version   int               `json:"version"`
+	OrgID     *identity.ID      `json:"org_id"`
+	KeyID     *identity.ID      `json:"key_id"`
+	KeyType   primitive.KeyType `json:"key_type"`
+	Created   time.Time         `json:"created"`
+	PublicKey []byte            `json:"public_key"`
+	Signature []byte            `json:"signature"`
+}
+
+const armorHeader = "-----BEGIN TORUS PUBLIC KEY-----"
+const armorFooter = "-----END TORUS PUBLIC KEY-----"
+
+// Export retrieves the org's first non-revoked keypair of keyType and
+// returns it as an armored, ASCII
+// block containing the key type, org ID, creation time, and a detached
+// self-signature, so it can be handed to another user for out-of-band
+// verification without going through the registry.
+func (k *KeypairsClient) Export(ctx context.Context, orgID *identity.ID, keyType primitive.KeyType) ([]byte, error) {
+	keypairs, err := k.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kp := range keypairs {
+		if kp.Revoked() || kp.PublicKey.Body.KeyType != keyType {
+			continue
+		}
+
+		sig, err := k.client.CryptoEngine.Sign(ctx, kp.PublicKey.Body.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		exported := ExportedKey{
+			Version:   exportedKeyVersion,
+			OrgID:     orgID,
+			KeyID:     kp.PublicKey.ID,
+			KeyType:   keyType,
+			Created:   kp.PublicKey.Body.Created,
+			PublicKey: kp.PublicKey.Body.Value,
+			Signature: sig,
+		}
+
+		return armor(exported)
+	}
+
+	return nil, errors.New("no matching keypair found")
+}
+
+// armor serializes an ExportedKey as base64-encoded JSON wrapped in
+// ASCII header/footer lines, mirroring the shape of a PGP armored block
+// without pulling in an OpenPGP dependency.
+func armor(key ExportedKey) ([]byte, error) {
+	raw, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(raw)
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, armorHeader)
+	for i := 0; i < len(encoded); i += 64 {
+		end := i + 64
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		fmt.Fprintln(&buf, encoded[i:end])
+	}
+	fmt.Fprintln(&buf, armorFooter)
+
+	return buf.Bytes(), nil
+}
+
+// Dearmor reverses armor, returning the decoded ExportedKey.
+func Dearmor(block []byte) (*ExportedKey, error) {
+	trimmed := bytes.TrimSpace(block)
+	trimmed = bytes.TrimPrefix(trimmed, []byte(armorHeader))
+	trimmed = bytes.TrimSuffix(trimmed, []byte(armorFooter))
+
+	raw, err := base64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(trimmed), nil)))
+	if err != nil {
+		return nil, errors.New("malformed key block")
+	}
+
+	key := &ExportedKey{}
+	if err := json.Unmarshal(raw, key); err != nil {
+		return nil, errors.New("malformed key block")
+	}
+
+	return key, nil
+}
+
+// Fingerprint returns a short SHA-256 fingerprint of the public key for
+// comparison over voice: the first 40 hex characters of the digest, as one
+// ungrouped string.
+func (e *ExportedKey) Fingerprint() string {
+	sum := sha256.Sum256(e.PublicKey)
+	return fmt.Sprintf("%x", sum)[:40]
+}
+
+// Verify checks the detached self-signature on an exported key, returning
+// an error if the block has been tampered with or was never validly
+// signed by the crypto-engine that generated it.
+func (k *KeypairsClient) Verify(ctx context.Context, key *ExportedKey) error {
+	ok, err := k.client.CryptoEngine.Verify(ctx, key.PublicKey, key.Signature)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("self-signature verification failed")
+	}
+	return nil
+}
+
+// Import validates an exported key's self-signature and pins it in the
+// local trust cache, so subsequent signature verification on objects from
+// that org can consult the pinned key without a registry round-trip.
+func (k *KeypairsClient) Import(ctx context.Context, block []byte) (*ExportedKey, error) {
+	key, err := Dearmor(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := k.Verify(ctx, key); err != nil {
+		return nil, err
+	}
+
+	if err := trustcache.Put(key.OrgID, key.KeyID, block); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
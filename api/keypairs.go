@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/manifoldco/torus-cli/identity"
+	"github.com/manifoldco/torus-cli/primitive"
+)
+
+// KeypairsClient makes proxied requests to the registry's keypairs endpoints
+type KeypairsClient struct {
+	client *Client
+}
+
+// KeypairResult is the payload returned for a keypair object
+type KeypairResult struct {
+	PublicKey  PublicKeySegment   `json:"public_key"`
+	PrivateKey *PrivateKeySegment `json:"private_key,omitempty"`
+}
+
+// PublicKeySegment is the public half of a keypair
+type PublicKeySegment struct {
+	ID   *identity.ID         `json:"id"`
+	Body *primitive.PublicKey `json:"body"`
+}
+
+// PrivateKeySegment is the private half of a keypair, only returned to the
+// owning user
+type PrivateKeySegment struct {
+	ID   *identity.ID          `json:"id"`
+	Body *primitive.PrivateKey `json:"body"`
+}
+
+// Revoked returns whether or not this keypair has been revoked
+func (k *KeypairResult) Revoked() bool {
+	return k.PublicKey.Body.Revoked()
+}
+
+// List retrieves the active and revoked keypairs for an org, walking the
+// registry's paginated /keypairs endpoint so large orgs don't have to be
+// materialized in a single round-trip.
+func (k *KeypairsClient) List(ctx context.Context, orgID *identity.ID) ([]KeypairResult, error) {
+	v := url.Values{}
+	if orgID != nil {
+		v.Set("org_id", orgID.String())
+	}
+
+	keypairs := []KeypairResult{}
+
+	pager := NewPager(k.client, "/keypairs", v)
+	err := pager.Each(ctx,
+		func() interface{} { return &[]KeypairResult{} },
+		func(elem interface{}) error {
+			keypairs = append(keypairs, elem.(KeypairResult))
+			return nil
+		},
+	)
+
+	return keypairs, err
+}
+
+// ListPage streams each page of keypairs to fn as it arrives, instead of
+// materializing the full list, for callers (like `keypairs list`) that
+// want to render output incrementally.
+func (k *KeypairsClient) ListPage(ctx context.Context, orgID *identity.ID, fn func(KeypairResult) error) error {
+	v := url.Values{}
+	if orgID != nil {
+		v.Set("org_id", orgID.String())
+	}
+
+	pager := NewPager(k.client, "/keypairs", v)
+	return pager.Each(ctx,
+		func() interface{} { return &[]KeypairResult{} },
+		func(elem interface{}) error {
+			return fn(elem.(KeypairResult))
+		},
+	)
+}
+
+// Generate creates a new signing and encryption keypair for the given org.
+// progress is passed on to Client.Do, which does not call it.
+func (k *KeypairsClient) Generate(ctx context.Context, orgID *identity.ID, progress *ProgressFunc) error {
+	if orgID == nil {
+		return errors.New("invalid org")
+	}
+
+	req, _, err := k.client.NewRequest("POST", "/keypairs/generate", nil, map[string]*identity.ID{
+		"org_id": orgID,
+	}, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = k.client.Do(ctx, req, nil, progress, nil)
+	return err
+}
+
+// ProgressFunc is the status callback that long-running keypairs
+// operations (generate, rotate) accept and pass on to Client.Do. Client.Do
+// does not call it, so no status updates are reported through it.
+type ProgressFunc func(msg string)
+
+// Revoke marks the keypair with the given public key ID as revoked, and
+// confirms the registry has recorded the revocation before returning.
+func (k *KeypairsClient) Revoke(ctx context.Context, orgID *identity.ID, keyID *identity.ID) error {
+	if orgID == nil {
+		return errors.New("invalid org")
+	}
+	if keyID == nil {
+		return errors.New("invalid keypair id")
+	}
+
+	v := &url.Values{}
+	v.Set("org_id", orgID.String())
+
+	req, _, err := k.client.NewRequest("POST", "/keypairs/"+keyID.String()+"/revoke", v, nil, true)
+	if err != nil {
+		return err
+	}
+
+	if _, err := k.client.Do(ctx, req, nil, nil, nil); err != nil {
+		return err
+	}
+
+	return k.verifyRevoked(ctx, orgID, keyID)
+}
+
+// verifyRevoked re-fetches the org's keypairs and confirms the target
+// keypair is now reported as revoked by the registry.
+func (k *KeypairsClient) verifyRevoked(ctx context.Context, orgID *identity.ID, keyID *identity.ID) error {
+	keypairs, err := k.List(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, kp := range keypairs {
+		if kp.PublicKey.ID.String() != keyID.String() {
+			continue
+		}
+		if !kp.Revoked() {
+			return errors.New("revocation did not take effect")
+		}
+		return nil
+	}
+
+	return errors.New("keypair not found after revocation")
+}
+
+// Rotate generates a new signing and encryption keypair for an org,
+// re-encrypts any user-accessible secrets under the new encryption key, and
+// revokes the previous keypairs once the re-encryption has completed.
+func (k *KeypairsClient) Rotate(ctx context.Context, orgID *identity.ID, progress *ProgressFunc) error {
+	if orgID == nil {
+		return errors.New("invalid org")
+	}
+
+	old, err := k.List(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	if err := k.Generate(ctx, orgID, progress); err != nil {
+		return err
+	}
+
+	if err := k.client.Credentials.ReEncrypt(ctx, orgID, progress); err != nil {
+		return err
+	}
+
+	for _, kp := range old {
+		if kp.Revoked() {
+			continue
+		}
+		if err := k.Revoke(ctx, orgID, kp.PublicKey.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
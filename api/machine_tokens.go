@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/manifoldco/torus-cli/identity"
+	"github.com/manifoldco/torus-cli/primitive"
+)
+
+// MachineTokensClient makes proxied requests to the registry's machine
+// token endpoints
+type MachineTokensClient struct {
+	client *Client
+}
+
+// MachineTokenResult is the payload returned for a machine token object.
+// Secret is only populated once, on the response to Create.
+type MachineTokenResult struct {
+	ID     *identity.ID            `json:"id"`
+	Body   *primitive.MachineToken `json:"body"`
+	Secret string                  `json:"secret,omitempty"`
+}
+
+// Create issues a new bearer token for a machine, optionally expiring after
+// ttl. The returned Secret is shown once and cannot be retrieved again.
+func (m *MachineTokensClient) Create(ctx context.Context, machineID *identity.ID, ttl time.Duration) (*MachineTokenResult, error) {
+	if machineID == nil {
+		return nil, errors.New("invalid machine")
+	}
+
+	tokenBody := primitive.MachineToken{
+		MachineID: machineID,
+		State:     primitive.MachineTokenActive,
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		tokenBody.ExpiresAt = &expiresAt
+	}
+
+	req, _, err := m.client.NewRequest("POST", "/machines/"+machineID.String()+"/tokens", nil, tokenBody, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MachineTokenResult{}
+	_, err = m.client.Do(ctx, req, result, nil, nil)
+	return result, err
+}
+
+// List retrieves the tokens issued to a machine. Secret is never included.
+func (m *MachineTokensClient) List(ctx context.Context, machineID *identity.ID) ([]MachineTokenResult, error) {
+	if machineID == nil {
+		return nil, errors.New("invalid machine")
+	}
+
+	v := &url.Values{}
+	v.Set("machine_id", machineID.String())
+
+	req, _, err := m.client.NewRequest("GET", "/machines/"+machineID.String()+"/tokens", v, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []MachineTokenResult{}
+	_, err = m.client.Do(ctx, req, &tokens, nil, nil)
+	return tokens, err
+}
+
+// Revoke invalidates a single machine token without affecting the machine
+// identity or its other tokens.
+func (m *MachineTokensClient) Revoke(ctx context.Context, tokenID *identity.ID) error {
+	if tokenID == nil {
+		return errors.New("invalid token")
+	}
+
+	req, _, err := m.client.NewRequest("POST", "/machines/tokens/"+tokenID.String()+"/revoke", nil, nil, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(ctx, req, nil, nil, nil)
+	return err
+}
+
+// Rotate issues a new token expiring after ttl (never, if ttl is zero) and
+// then revokes the given one, so automation can cycle credentials without
+// downtime planning.
+func (m *MachineTokensClient) Rotate(ctx context.Context, machineID, tokenID *identity.ID, ttl time.Duration) (*MachineTokenResult, error) {
+	next, err := m.Create(ctx, machineID, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.Revoke(ctx, tokenID); err != nil {
+		return next, err
+	}
+
+	return next, nil
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/manifoldco/torus-cli/apitypes"
+	"github.com/manifoldco/torus-cli/identity"
+	"github.com/manifoldco/torus-cli/primitive"
+)
+
+// MachinesClient makes proxied requests to the registry's machines endpoints
+type MachinesClient struct {
+	client *Client
+}
+
+// MachineResult is the payload returned for a machine object
+type MachineResult struct {
+	ID      *identity.ID       `json:"id"`
+	Version uint8              `json:"version"`
+	Body    *primitive.Machine `json:"body"`
+	Teams   []*identity.ID     `json:"teams,omitempty"`
+}
+
+// Create provisions a new machine identity inside an org, optionally
+// assigning it to one or more teams through the existing TeamsClient
+// membership endpoints.
+func (m *MachinesClient) Create(ctx context.Context, orgID *identity.ID, name string, teamIDs []*identity.ID) (*MachineResult, error) {
+	if orgID == nil {
+		return nil, errors.New("invalid org")
+	}
+
+	machineBody := primitive.Machine{
+		Name:  name,
+		OrgID: orgID,
+		State: primitive.MachineActive,
+	}
+
+	ID, err := identity.NewMutable(&machineBody)
+	if err != nil {
+		return nil, err
+	}
+
+	machine := apitypes.Machine{
+		ID:      &ID,
+		Version: 1,
+		Body:    &machineBody,
+	}
+
+	req, _, err := m.client.NewRequest("POST", "/machines", nil, machine, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MachineResult{}
+	if _, err := m.client.Do(ctx, req, result, nil, nil); err != nil {
+		return nil, err
+	}
+
+	for _, teamID := range teamIDs {
+		if _, err := m.client.Teams.AddMember(ctx, teamID, result.ID); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// List retrieves the machines for an org
+func (m *MachinesClient) List(ctx context.Context, orgID *identity.ID, name string) ([]MachineResult, error) {
+	v := &url.Values{}
+	if orgID != nil {
+		v.Set("org_id", orgID.String())
+	}
+	if name != "" {
+		v.Set("name", name)
+	}
+
+	req, _, err := m.client.NewRequest("GET", "/machines", v, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	machines := []MachineResult{}
+	_, err = m.client.Do(ctx, req, &machines, nil, nil)
+	return machines, err
+}
+
+// Destroy deactivates a machine identity. Any tokens issued to it stop
+// being valid immediately.
+func (m *MachinesClient) Destroy(ctx context.Context, machineID *identity.ID) error {
+	if machineID == nil {
+		return errors.New("invalid machine")
+	}
+
+	req, _, err := m.client.NewRequest("DELETE", "/machines/"+machineID.String(), nil, nil, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(ctx, req, nil, nil, nil)
+	return err
+}
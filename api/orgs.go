@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/manifoldco/torus-cli/identity"
+	"github.com/manifoldco/torus-cli/primitive"
+)
+
+// OrgsClient makes proxied requests to the registry's orgs endpoints
+type OrgsClient struct {
+	client *Client
+}
+
+// OrgResult is the payload returned for an org object
+type OrgResult struct {
+	ID      *identity.ID   `json:"id"`
+	Version uint8          `json:"version"`
+	Body    *primitive.Org `json:"body"`
+}
+
+// List retrieves every org the current session can see
+func (o *OrgsClient) List(ctx context.Context) ([]OrgResult, error) {
+	req, _, err := o.client.NewRequest("GET", "/orgs", nil, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := []OrgResult{}
+	_, err = o.client.Do(ctx, req, &orgs, nil, nil)
+	return orgs, err
+}
+
+// GetByName retrieves the org with the given name, or nil if none matches
+func (o *OrgsClient) GetByName(ctx context.Context, name string) (*OrgResult, error) {
+	v := &url.Values{}
+	v.Set("name", name)
+
+	req, _, err := o.client.NewRequest("GET", "/orgs", v, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := []OrgResult{}
+	if _, err := o.client.Do(ctx, req, &orgs, nil, nil); err != nil {
+		return nil, err
+	}
+	if len(orgs) == 0 {
+		return nil, nil
+	}
+
+	return &orgs[0], nil
+}
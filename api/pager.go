@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// defaultPerPage is the page size requested when a caller does not need
+// fine control over memory/latency tradeoffs.
+const defaultPerPage = 100
+
+// Pager transparently walks a paginated registry endpoint, issuing one GET
+// per page and handing decoded results to the caller as they arrive so
+// memory use stays bounded regardless of collection size.
+type Pager struct {
+	client  *Client
+	path    string
+	values  url.Values
+	perPage int
+}
+
+// NewPager returns a Pager that will GET path with the given query values,
+// overriding any existing page/per_page values on each request.
+func NewPager(client *Client, path string, values url.Values) *Pager {
+	if values == nil {
+		values = url.Values{}
+	}
+
+	return &Pager{
+		client:  client,
+		path:    path,
+		values:  values,
+		perPage: defaultPerPage,
+	}
+}
+
+// Each decodes every page of results into a fresh value returned by into
+// (a pointer to a slice), and invokes fn once per element in page order. It stops at the first page that returns fewer than perPage
+// results, or the first error from either the request or fn.
+func (p *Pager) Each(ctx context.Context, into func() interface{}, fn func(interface{}) error) error {
+	page := 1
+
+	for {
+		if err := cancelledErr(ctx); err != nil {
+			return err
+		}
+
+		v := url.Values{}
+		for k, vals := range p.values {
+			v[k] = vals
+		}
+		v.Set("page", strconv.Itoa(page))
+		v.Set("per_page", strconv.Itoa(p.perPage))
+
+		req, _, err := p.client.NewRequest("GET", p.path, &v, nil, true)
+		if err != nil {
+			return err
+		}
+
+		result := into()
+		if _, err := p.client.Do(ctx, req, result, nil, nil); err != nil {
+			return err
+		}
+
+		n, err := eachElem(result, fn)
+		if err != nil {
+			return err
+		}
+		if n < p.perPage {
+			return nil
+		}
+
+		page++
+	}
+}
+
+// eachElem calls fn with each element of the slice pointed to by result,
+// returning the number of elements visited.
+func eachElem(result interface{}, fn func(interface{}) error) (int, error) {
+	v := reflect.ValueOf(result)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		if err := fn(v.Index(i).Interface()); err != nil {
+			return i, err
+		}
+	}
+
+	return v.Len(), nil
+}
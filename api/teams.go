@@ -24,7 +24,7 @@ type TeamResult struct {
 
 // List retrieves all teams for an org based on the filtered values
 func (t *TeamsClient) List(ctx context.Context, orgID *identity.ID, name, teamType string) ([]TeamResult, error) {
-	v := &url.Values{}
+	v := url.Values{}
 
 	if orgID != nil {
 		v.Set("org_id", orgID.String())
@@ -36,44 +36,40 @@ func (t *TeamsClient) List(ctx context.Context, orgID *identity.ID, name, teamTy
 		v.Set("type", teamType)
 	}
 
-	req, _, err := t.client.NewRequest("GET", "/teams", v, nil, true)
-	if err != nil {
-		return nil, err
-	}
-
-	teams := []TeamResult{}
-	_, err = t.client.Do(ctx, req, &teams, nil, nil)
-	return teams, err
+	return t.listPaged(ctx, v)
 }
 
 // GetByOrg retrieves all teams for an org id
 func (t *TeamsClient) GetByOrg(ctx context.Context, orgID *identity.ID) ([]TeamResult, error) {
-	v := &url.Values{}
+	v := url.Values{}
 	v.Set("org_id", orgID.String())
 
-	req, _, err := t.client.NewRequest("GET", "/teams", v, nil, true)
-	if err != nil {
-		return nil, err
-	}
-
-	teams := []TeamResult{}
-	_, err = t.client.Do(ctx, req, &teams, nil, nil)
-	return teams, err
+	return t.listPaged(ctx, v)
 }
 
 // GetByName retrieves the team with the specified name
 func (t *TeamsClient) GetByName(ctx context.Context, orgID *identity.ID, name string) ([]TeamResult, error) {
-	v := &url.Values{}
+	v := url.Values{}
 	v.Set("org_id", orgID.String())
 	v.Set("name", name)
 
-	req, _, err := t.client.NewRequest("GET", "/teams", v, nil, true)
-	if err != nil {
-		return nil, err
-	}
+	return t.listPaged(ctx, v)
+}
 
+// listPaged walks /teams a page at a time via the shared Pager, so large
+// orgs don't require materializing the whole team list in one round-trip.
+func (t *TeamsClient) listPaged(ctx context.Context, v url.Values) ([]TeamResult, error) {
 	teams := []TeamResult{}
-	_, err = t.client.Do(ctx, req, &teams, nil, nil)
+
+	pager := NewPager(t.client, "/teams", v)
+	err := pager.Each(ctx,
+		func() interface{} { return &[]TeamResult{} },
+		func(elem interface{}) error {
+			teams = append(teams, elem.(TeamResult))
+			return nil
+		},
+	)
+
 	return teams, err
 }
 
@@ -114,3 +110,24 @@ func (t *TeamsClient) Create(
 	_, err = t.client.Do(ctx, req, teamResult, nil, nil)
 	return teamResult, err
 }
+
+// AddMember adds an identity (user or machine) to a team's membership
+func (t *TeamsClient) AddMember(ctx context.Context, teamID, memberID *identity.ID) (*apitypes.Membership, error) {
+	if teamID == nil || memberID == nil {
+		return nil, errors.New("invalid team or member")
+	}
+
+	membership := apitypes.Membership{
+		TeamID:  teamID,
+		OwnerID: memberID,
+	}
+
+	req, _, err := t.client.NewRequest("POST", "/memberships", nil, membership, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &apitypes.Membership{}
+	_, err = t.client.Do(ctx, req, result, nil, nil)
+	return result, err
+}
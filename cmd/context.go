@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// signalContext returns a context that is cancelled the moment the process
+// receives SIGINT or SIGTERM, so an in-flight daemon request (keypairs
+// generate, rotate, etc.) can unwind instead of leaving the terminal stuck
+// after a Ctrl-C.
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestSignalContextCancelsOnSIGINT spawns a slow mock daemon and verifies
+// that sending SIGINT to the process unblocks a request using
+// signalContext well before the daemon would have responded on its own.
+func TestSignalContextCancelsOnSIGINT(t *testing.T) {
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-unblock:
+		}
+	}))
+	defer srv.Close()
+	defer close(unblock)
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	req, err := http.NewRequest("GET", srv.URL, nil)
+	if err != nil {
+		t.Fatalf("could not build request: %s", err)
+	}
+	req = req.WithContext(ctx)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := http.DefaultClient.Do(req)
+		done <- err
+	}()
+
+	// Give the request a moment to actually reach the handler before
+	// interrupting, so we're not racing process startup.
+	time.Sleep(20 * time.Millisecond)
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatalf("could not signal self: %s", err)
+	}
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected request to be cancelled, got nil error")
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("SIGINT did not unblock the in-flight request within 100ms")
+	}
+}
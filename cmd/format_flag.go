@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/manifoldco/torus-cli/cmd/output"
+)
+
+// formatFlag must be added explicitly to each list-style subcommand's Flags
+// so the caller can choose table, json, or yaml output; it mirrors the
+// pattern orgFlag uses for --org. It is not inherited from the root app --
+// see FormatFlagName's doc comment for why.
+//
+// TODO: the original request asked for a global, persistent --format flag
+// on the root cli.App. That part of the request is still unresolved, not
+// just differently implemented -- this tree has no file constructing the
+// root cli.App for the flag to be declared on, so doing it properly means
+// adding that file, which is out of scope for a keypairs-list-only change.
+func formatFlag() cli.Flag {
+	return cli.StringFlag{
+		Name:  output.FormatFlagName,
+		Usage: "Output format [table|json|yaml]",
+		Value: output.Table,
+	}
+}
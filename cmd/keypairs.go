@@ -3,13 +3,15 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
-	"text/tabwriter"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli"
 
 	"github.com/manifoldco/torus-cli/api"
+	"github.com/manifoldco/torus-cli/cmd/output"
 	"github.com/manifoldco/torus-cli/config"
 	"github.com/manifoldco/torus-cli/errs"
 	"github.com/manifoldco/torus-cli/identity"
@@ -27,6 +29,7 @@ func init() {
 				Usage: "List your keypairs for an organization",
 				Flags: []cli.Flag{
 					orgFlag("org to show keypairs for", true),
+					formatFlag(),
 				},
 				Action: chain(
 					ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
@@ -48,6 +51,68 @@ func init() {
 					setUserEnv, checkRequiredFlags, generateKeypairs,
 				),
 			},
+			{
+				Name:  "revoke",
+				Usage: "Revoke a keypair for an organization",
+				Flags: []cli.Flag{
+					orgFlag("org the keypair belongs to", true),
+				},
+				ArgsUsage: "<key-id>",
+				Action: chain(
+					ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+					setUserEnv, checkRequiredFlags, revokeKeypair,
+				),
+			},
+			{
+				Name:  "rotate",
+				Usage: "Rotate keypairs for an organization, re-encrypting secrets under the new keys",
+				Flags: []cli.Flag{
+					orgFlag("org to rotate keypairs for", false),
+					cli.BoolFlag{
+						Name:  "all",
+						Usage: "Perform command for all orgs",
+					},
+				},
+				Action: chain(
+					ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+					setUserEnv, checkRequiredFlags, rotateKeypairs,
+				),
+			},
+			{
+				Name:  "export",
+				Usage: "Export the public half of a keypair in a portable armored format",
+				Flags: []cli.Flag{
+					orgFlag("org the keypair belongs to", true),
+					cli.StringFlag{
+						Name:  "type",
+						Usage: "Type of keypair to export [signing|encryption]",
+						Value: string(primitive.SigningKeyType),
+					},
+					cli.StringFlag{
+						Name:  "out",
+						Usage: "File to write the armored key to, defaults to stdout",
+					},
+				},
+				Action: chain(
+					ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+					setUserEnv, checkRequiredFlags, exportKeypair,
+				),
+			},
+			{
+				Name:      "import",
+				Usage:     "Import and trust another user's exported public key",
+				ArgsUsage: "<file>",
+				Action: chain(
+					ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+					setUserEnv, checkRequiredFlags, importKeypair,
+				),
+			},
+			{
+				Name:      "fingerprint",
+				Usage:     "Print a short fingerprint for an exported key, suitable for comparison over voice",
+				ArgsUsage: "<file>",
+				Action:    keypairFingerprint,
+			},
 		},
 	}
 	Cmds = append(Cmds, keypairs)
@@ -62,39 +127,120 @@ func listKeypairs(ctx *cli.Context) error {
 	}
 
 	client := api.NewClient(cfg)
-	c := context.Background()
+	c, cancel := signalContext()
+	defer cancel()
 
 	// Look up the target org
 	var org *api.OrgResult
 	org, err = client.Orgs.GetByName(c, ctx.String("org"))
 	if err != nil {
+		if errs.IsCancelled(err) {
+			return err
+		}
 		return errs.NewExitError(keypairListFailed)
 	}
 	if org == nil {
 		return errs.NewExitError("Org not found.")
 	}
 
-	keypairs, err := client.Keypairs.List(c, org.ID)
+	format := ctx.String(output.FormatFlagName)
+	if format == "" {
+		format = output.Table
+	}
+
+	// table hands each keypair to the tabwriter as its page arrives, without
+	// collecting the results in a slice; the tabwriter itself holds all rows
+	// until Flush, so memory still grows with the number of keypairs.
+	// json/yaml have to emit one document, so they need the full result set
+	// first.
+	if format == output.Table {
+		return renderKeypairsTable(c, client, org)
+	}
+
+	views := []KeypairView{}
+	err = client.Keypairs.ListPage(c, org.ID, func(keypair api.KeypairResult) error {
+		views = append(views, newKeypairView(org.Body.Name, keypair))
+		return nil
+	})
 	if err != nil {
+		if errs.IsCancelled(err) {
+			return err
+		}
 		return errs.NewExitError(keypairListFailed)
 	}
 
+	if err := output.Render(output.DefaultWriter, format, views); err != nil {
+		return errs.NewExitError(err.Error())
+	}
+
+	return nil
+}
+
+func renderKeypairsTable(c context.Context, client *api.Client, org *api.OrgResult) error {
 	fmt.Println("")
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "ID\tORG\tKEY TYPE\tVALID\tCREATION DATE")
-	fmt.Fprintln(w, " \t \t \t \t ")
-	for _, keypair := range keypairs {
-		pk := keypair.PublicKey.Body
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", keypair.PublicKey.ID,
-			org.Body.Name, pk.KeyType, "YES", pk.Created.Format(time.RFC3339))
+	tw := output.NewTableWriter(os.Stdout)
+	err := client.Keypairs.ListPage(c, org.ID, func(keypair api.KeypairResult) error {
+		return tw.WriteRow(newKeypairView(org.Body.Name, keypair))
+	})
+	if err != nil {
+		if errs.IsCancelled(err) {
+			return err
+		}
+		return errs.NewExitError(keypairListFailed)
 	}
-	w.Flush()
+
+	tw.Flush()
 	fmt.Println("")
 
 	return nil
 }
 
+// KeypairView is the stable, scriptable shape `keypairs list` renders,
+// independent of the wire representation returned by the registry.
+type KeypairView struct {
+	ID        string `json:"id" yaml:"id"`
+	Org       string `json:"org" yaml:"org"`
+	KeyType   string `json:"key_type" yaml:"key_type"`
+	Valid     bool   `json:"valid" yaml:"valid"`
+	Created   string `json:"created" yaml:"created"`
+	Revoked   bool   `json:"revoked" yaml:"revoked"`
+	RevokedAt string `json:"revoked_at,omitempty" yaml:"revoked_at,omitempty"`
+}
+
+func newKeypairView(orgName string, keypair api.KeypairResult) KeypairView {
+	pk := keypair.PublicKey.Body
+	revoked := keypair.Revoked()
+
+	view := KeypairView{
+		ID:      keypair.PublicKey.ID.String(),
+		Org:     orgName,
+		KeyType: string(pk.KeyType),
+		Valid:   !revoked,
+		Created: pk.Created.Format(time.RFC3339),
+		Revoked: revoked,
+	}
+	if revoked && pk.RevokedAt != nil {
+		view.RevokedAt = pk.RevokedAt.Format(time.RFC3339)
+	}
+
+	return view
+}
+
+// Header implements output.Row
+func (KeypairView) Header() []string {
+	return []string{"ID", "ORG", "KEY TYPE", "VALID", "CREATION DATE"}
+}
+
+// Columns implements output.Row
+func (v KeypairView) Columns() []string {
+	valid := "YES"
+	if !v.Valid {
+		valid = "NO"
+	}
+	return []string{v.ID, v.Org, v.KeyType, valid, v.Created}
+}
+
 func generateKeypairs(ctx *cli.Context) error {
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -102,7 +248,8 @@ func generateKeypairs(ctx *cli.Context) error {
 	}
 
 	client := api.NewClient(cfg)
-	c := context.Background()
+	c, cancel := signalContext()
+	defer cancel()
 
 	orgNames := make(map[*identity.ID]string)
 	subjectOrgs := make(map[*identity.ID]*api.OrgResult)
@@ -158,6 +305,9 @@ func generateKeypairs(ctx *cli.Context) error {
 	}
 
 	if pErr != nil {
+		if errs.IsCancelled(pErr) {
+			return pErr
+		}
 		return errs.NewExitError("Error fetching required context.")
 	}
 
@@ -180,6 +330,9 @@ func generateKeypairs(ctx *cli.Context) error {
 	}
 
 	if rErr != nil {
+		if errs.IsCancelled(rErr) {
+			return rErr
+		}
 		return errs.NewExitError("Error while regenerating keypairs.")
 	}
 
@@ -217,3 +370,181 @@ func generateKeypairsForOrg(c context.Context, ctx *cli.Context, client *api.Cli
 
 	return nil
 }
+
+const keypairRevokeFailed = "Could not revoke keypair, please try again."
+
+func revokeKeypair(ctx *cli.Context) error {
+	keyID := ctx.Args().First()
+	if keyID == "" {
+		return errs.NewExitError("Missing key ID.")
+	}
+	id, err := identity.DecodeFromString(keyID)
+	if err != nil {
+		return errs.NewExitError("Invalid key ID.")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	org, err := client.Orgs.GetByName(c, ctx.String("org"))
+	if err != nil || org == nil {
+		return errs.NewExitError("Org not found.")
+	}
+
+	if err := client.Keypairs.Revoke(c, org.ID, &id); err != nil {
+		return errs.NewExitError(keypairRevokeFailed)
+	}
+
+	fmt.Println("Keypair revoked.")
+	return nil
+}
+
+func rotateKeypairs(ctx *cli.Context) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	subjectOrgs := make(map[*identity.ID]*api.OrgResult)
+
+	if ctx.Bool("all") {
+		orgs, oErr := client.Orgs.List(c)
+		if oErr != nil {
+			return errs.NewExitError("Could not retrieve orgs, please try again.")
+		}
+		for _, org := range orgs {
+			org := org
+			subjectOrgs[org.ID] = &org
+		}
+	} else {
+		orgName := ctx.String("org")
+		if orgName == "" {
+			return errs.NewExitError("Missing flags: --org.")
+		}
+		org, oErr := client.Orgs.GetByName(c, orgName)
+		if oErr != nil || org == nil {
+			return errs.NewExitError("Org '" + orgName + "' not found.")
+		}
+		subjectOrgs[org.ID] = org
+	}
+
+	failed := []string{}
+	rotated := []string{}
+	for orgID, org := range subjectOrgs {
+		fmt.Println("Rotating keypairs for org: " + org.Body.Name)
+		if err := client.Keypairs.Rotate(c, orgID, &progress); err != nil {
+			failed = append(failed, org.Body.Name)
+			continue
+		}
+		rotated = append(rotated, org.Body.Name)
+	}
+
+	fmt.Println("")
+	if len(rotated) > 0 {
+		fmt.Println("Rotated keypairs for: " + strings.Join(rotated, ", "))
+	}
+	if len(failed) > 0 {
+		fmt.Println("Failed to rotate keypairs for: " + strings.Join(failed, ", "))
+		return errs.NewExitError("Some orgs failed to rotate, see above.")
+	}
+
+	return nil
+}
+
+func exportKeypair(ctx *cli.Context) error {
+	keyType := primitive.KeyType(ctx.String("type"))
+	if keyType != primitive.SigningKeyType && keyType != primitive.EncryptionKeyType {
+		return errs.NewExitError("--type must be one of signing, encryption.")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	org, err := client.Orgs.GetByName(c, ctx.String("org"))
+	if err != nil || org == nil {
+		return errs.NewExitError("Org not found.")
+	}
+
+	block, err := client.Keypairs.Export(c, org.ID, keyType)
+	if err != nil {
+		return errs.NewExitError("Could not export keypair, please try again.")
+	}
+
+	out := ctx.String("out")
+	if out == "" {
+		fmt.Print(string(block))
+		return nil
+	}
+
+	if err := ioutil.WriteFile(out, block, 0600); err != nil {
+		return errs.NewExitError("Could not write key to " + out + ".")
+	}
+
+	fmt.Println("Key written to " + out)
+	return nil
+}
+
+func importKeypair(ctx *cli.Context) error {
+	file := ctx.Args().First()
+	if file == "" {
+		return errs.NewExitError("Missing key file.")
+	}
+
+	block, err := ioutil.ReadFile(file)
+	if err != nil {
+		return errs.NewExitError("Could not read " + file + ".")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	key, err := client.Keypairs.Import(c, block)
+	if err != nil {
+		return errs.NewExitError("Could not import key: " + err.Error())
+	}
+
+	fmt.Println("Key trusted. Fingerprint: " + key.Fingerprint())
+	return nil
+}
+
+func keypairFingerprint(ctx *cli.Context) error {
+	file := ctx.Args().First()
+	if file == "" {
+		return errs.NewExitError("Missing key file.")
+	}
+
+	block, err := ioutil.ReadFile(file)
+	if err != nil {
+		return errs.NewExitError("Could not read " + file + ".")
+	}
+
+	key, err := api.Dearmor(block)
+	if err != nil {
+		return errs.NewExitError("Could not parse key file.")
+	}
+
+	fmt.Println(key.Fingerprint())
+	return nil
+}
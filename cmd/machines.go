@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/manifoldco/torus-cli/api"
+	"github.com/manifoldco/torus-cli/config"
+	"github.com/manifoldco/torus-cli/errs"
+	"github.com/manifoldco/torus-cli/identity"
+)
+
+func init() {
+	machines := cli.Command{
+		Name:     "machines",
+		Usage:    "View and create machine identities for an organization",
+		Category: "ORGANIZATIONS",
+		Subcommands: []cli.Command{
+			{
+				Name:  "create",
+				Usage: "Create a machine identity for an organization",
+				Flags: []cli.Flag{
+					orgFlag("org to create the machine in", true),
+					cli.StringSliceFlag{
+						Name:  "team",
+						Usage: "Team(s) to assign the machine to",
+					},
+				},
+				ArgsUsage: "<name>",
+				Action: chain(
+					ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+					setUserEnv, checkRequiredFlags, createMachine,
+				),
+			},
+			{
+				Name:  "list",
+				Usage: "List machines for an organization",
+				Flags: []cli.Flag{
+					orgFlag("org to list machines for", true),
+				},
+				Action: chain(
+					ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+					setUserEnv, checkRequiredFlags, listMachines,
+				),
+			},
+			{
+				Name:      "destroy",
+				Usage:     "Destroy a machine identity",
+				ArgsUsage: "<id>",
+				Action: chain(
+					ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+					setUserEnv, checkRequiredFlags, destroyMachine,
+				),
+			},
+			{
+				Name:  "tokens",
+				Usage: "View and create tokens for a machine",
+				Subcommands: []cli.Command{
+					{
+						Name:      "create",
+						Usage:     "Create a token for a machine",
+						ArgsUsage: "<machine-id>",
+						Flags: []cli.Flag{
+							cli.DurationFlag{
+								Name:  "ttl",
+								Usage: "Time the token remains valid for, e.g. 720h (0 for no expiry)",
+							},
+						},
+						Action: chain(
+							ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+							setUserEnv, checkRequiredFlags, createMachineToken,
+						),
+					},
+					{
+						Name:      "list",
+						Usage:     "List tokens for a machine",
+						ArgsUsage: "<machine-id>",
+						Action: chain(
+							ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+							setUserEnv, checkRequiredFlags, listMachineTokens,
+						),
+					},
+					{
+						Name:      "revoke",
+						Usage:     "Revoke a machine token",
+						ArgsUsage: "<token-id>",
+						Action: chain(
+							ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+							setUserEnv, checkRequiredFlags, revokeMachineToken,
+						),
+					},
+					{
+						Name:      "rotate",
+						Usage:     "Rotate a machine token, revoking the old one and issuing a new one",
+						ArgsUsage: "<machine-id> <token-id>",
+						Flags: []cli.Flag{
+							cli.DurationFlag{
+								Name:  "ttl",
+								Usage: "Time the new token remains valid for, e.g. 720h (0 for no expiry)",
+							},
+						},
+						Action: chain(
+							ensureDaemon, ensureSession, loadDirPrefs, loadPrefDefaults,
+							setUserEnv, checkRequiredFlags, rotateMachineToken,
+						),
+					},
+				},
+			},
+		},
+	}
+	Cmds = append(Cmds, machines)
+}
+
+func createMachine(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		return errs.NewExitError("Missing machine name.")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	org, err := client.Orgs.GetByName(c, ctx.String("org"))
+	if err != nil || org == nil {
+		return errs.NewExitError("Org not found.")
+	}
+
+	var teamIDs []*identity.ID
+	for _, teamName := range ctx.StringSlice("team") {
+		teams, tErr := client.Teams.GetByName(c, org.ID, teamName)
+		if tErr != nil || len(teams) < 1 {
+			return errs.NewExitError("Team '" + teamName + "' not found.")
+		}
+		teamIDs = append(teamIDs, teams[0].ID)
+	}
+
+	machine, err := client.Machines.Create(c, org.ID, name, teamIDs)
+	if err != nil {
+		if machine != nil {
+			return errs.NewExitError("Machine '" + name + "' created with ID " + machine.ID.String() +
+				", but could not be assigned to one or more teams. Retrying will create a second," +
+				" orphaned machine -- fix up team membership on " + machine.ID.String() + " instead.")
+		}
+		return errs.NewExitError("Could not create machine, please try again.")
+	}
+
+	fmt.Println("Machine '" + name + "' created with ID " + machine.ID.String())
+	return nil
+}
+
+func listMachines(ctx *cli.Context) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	org, err := client.Orgs.GetByName(c, ctx.String("org"))
+	if err != nil || org == nil {
+		return errs.NewExitError("Org not found.")
+	}
+
+	machines, err := client.Machines.List(c, org.ID, "")
+	if err != nil {
+		return errs.NewExitError("Could not list machines, please try again.")
+	}
+
+	fmt.Println("")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tSTATE")
+	fmt.Fprintln(w, " \t \t ")
+	for _, machine := range machines {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", machine.ID, machine.Body.Name, machine.Body.State)
+	}
+	w.Flush()
+	fmt.Println("")
+
+	return nil
+}
+
+func destroyMachine(ctx *cli.Context) error {
+	machineID := ctx.Args().First()
+	if machineID == "" {
+		return errs.NewExitError("Missing machine ID.")
+	}
+	id, err := identity.DecodeFromString(machineID)
+	if err != nil {
+		return errs.NewExitError("Invalid machine ID.")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	if err := client.Machines.Destroy(c, &id); err != nil {
+		return errs.NewExitError("Could not destroy machine, please try again.")
+	}
+
+	fmt.Println("Machine destroyed.")
+	return nil
+}
+
+func createMachineToken(ctx *cli.Context) error {
+	machineID := ctx.Args().First()
+	if machineID == "" {
+		return errs.NewExitError("Missing machine ID.")
+	}
+	id, err := identity.DecodeFromString(machineID)
+	if err != nil {
+		return errs.NewExitError("Invalid machine ID.")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	token, err := client.MachineTokens.Create(c, &id, ctx.Duration("ttl"))
+	if err != nil {
+		return errs.NewExitError("Could not create token, please try again.")
+	}
+
+	fmt.Println("Token created. This secret will only be shown once:")
+	fmt.Println("")
+	fmt.Println(token.Secret)
+	fmt.Println("")
+	return nil
+}
+
+func listMachineTokens(ctx *cli.Context) error {
+	machineID := ctx.Args().First()
+	if machineID == "" {
+		return errs.NewExitError("Missing machine ID.")
+	}
+	id, err := identity.DecodeFromString(machineID)
+	if err != nil {
+		return errs.NewExitError("Invalid machine ID.")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	tokens, err := client.MachineTokens.List(c, &id)
+	if err != nil {
+		return errs.NewExitError("Could not list tokens, please try again.")
+	}
+
+	fmt.Println("")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATE\tEXPIRES")
+	fmt.Fprintln(w, " \t \t ")
+	for _, token := range tokens {
+		expires := "never"
+		if token.Body.ExpiresAt != nil {
+			expires = token.Body.ExpiresAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", token.ID, token.Body.State, expires)
+	}
+	w.Flush()
+	fmt.Println("")
+
+	return nil
+}
+
+func revokeMachineToken(ctx *cli.Context) error {
+	tokenID := ctx.Args().First()
+	if tokenID == "" {
+		return errs.NewExitError("Missing token ID.")
+	}
+	id, err := identity.DecodeFromString(tokenID)
+	if err != nil {
+		return errs.NewExitError("Invalid token ID.")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	if err := client.MachineTokens.Revoke(c, &id); err != nil {
+		return errs.NewExitError("Could not revoke token, please try again.")
+	}
+
+	fmt.Println("Token revoked.")
+	return nil
+}
+
+func rotateMachineToken(ctx *cli.Context) error {
+	args := strings.Fields(strings.Join(ctx.Args(), " "))
+	if len(args) < 2 {
+		return errs.NewExitError("Usage: torus machines tokens rotate <machine-id> <token-id>")
+	}
+
+	machineID, err := identity.DecodeFromString(args[0])
+	if err != nil {
+		return errs.NewExitError("Invalid machine ID.")
+	}
+	tokenID, err := identity.DecodeFromString(args[1])
+	if err != nil {
+		return errs.NewExitError("Invalid token ID.")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	c, cancel := signalContext()
+	defer cancel()
+
+	token, err := client.MachineTokens.Rotate(c, &machineID, &tokenID, ctx.Duration("ttl"))
+	if err != nil {
+		return errs.NewExitError("Could not rotate token, please try again.")
+	}
+
+	fmt.Println("Token rotated. This secret will only be shown once:")
+	fmt.Println("")
+	fmt.Println(token.Secret)
+	fmt.Println("")
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package output renders list-style command results in a format chosen by
+// the caller, so the same data can back a human-readable table or a
+// scriptable json/yaml payload.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"text/tabwriter"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Supported output formats
+const (
+	Table = "table"
+	JSON  = "json"
+	YAML  = "yaml"
+)
+
+// Row is implemented by values that know how to lay themselves out as a
+// table row; Render falls back to it for the table format.
+type Row interface {
+	// Header returns the column titles for this type.
+	Header() []string
+	// Columns returns this row's values in the same order as Header.
+	Columns() []string
+}
+
+// Render writes v to w in the given format. json and yaml marshal v
+// directly; table requires v to be a []Row (or a type whose elements
+// implement Row).
+func Render(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case "", Table:
+		rows, ok := v.([]Row)
+		if !ok {
+			rv := reflect.ValueOf(v)
+			if rv.Kind() != reflect.Slice {
+				return fmt.Errorf("output: %T does not implement []output.Row", v)
+			}
+			rows = make([]Row, rv.Len())
+			for i := range rows {
+				row, ok := rv.Index(i).Interface().(Row)
+				if !ok {
+					return fmt.Errorf("output: %T does not implement []output.Row", v)
+				}
+				rows[i] = row
+			}
+		}
+		return renderTable(w, rows)
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case YAML:
+		raw, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(raw)
+		return err
+	default:
+		return fmt.Errorf("output: unknown format %q, must be one of table, json, yaml", format)
+	}
+}
+
+func renderTable(w io.Writer, rows []Row) error {
+	tw := NewTableWriter(w)
+	for _, row := range rows {
+		if err := tw.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// TableWriter incrementally writes Row values as a table, printing the
+// header on the first row written. Render's table format uses it for an
+// already materialized []Row; callers that page results, like
+// `keypairs list`, can use it directly to hand each row over as it arrives
+// without first collecting the rows in a slice. The underlying tabwriter
+// still holds every row until Flush, so nothing reaches w before then and
+// memory use grows with the number of rows.
+type TableWriter struct {
+	tw     *tabwriter.Writer
+	header bool
+}
+
+// NewTableWriter returns a TableWriter that writes to w. Callers must call
+// Flush once they're done writing rows.
+func NewTableWriter(w io.Writer) *TableWriter {
+	return &TableWriter{tw: tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)}
+}
+
+// WriteRow writes row's columns, first printing row's header (and a blank
+// separator line) if this is the first row written.
+func (t *TableWriter) WriteRow(row Row) error {
+	if !t.header {
+		header := row.Header()
+		fmt.Fprintln(t.tw, tabLine(header))
+
+		blank := make([]string, len(header))
+		for i := range blank {
+			blank[i] = " "
+		}
+		fmt.Fprintln(t.tw, tabLine(blank))
+
+		t.header = true
+	}
+
+	_, err := fmt.Fprintln(t.tw, tabLine(row.Columns()))
+	return err
+}
+
+// Flush writes any buffered output to the underlying writer.
+func (t *TableWriter) Flush() error {
+	return t.tw.Flush()
+}
+
+func tabLine(cols []string) string {
+	line := ""
+	for i, c := range cols {
+		if i > 0 {
+			line += "\t"
+		}
+		line += c
+	}
+	return line
+}
+
+// FormatFlagName is the flag name list-style subcommands read their output
+// format from. It isn't a true global flag on the root app -- urfave/cli
+// only inherits flags declared on cli.App itself, and this tree has no
+// file that constructs one -- so each list-style subcommand declares its
+// own copy via formatFlag() instead of inheriting one from the root.
+const FormatFlagName = "format"
+
+// DefaultWriter is the writer commands pass to Render when they don't need
+// to direct output elsewhere, kept as a var so tests can swap it.
+var DefaultWriter io.Writer = os.Stdout
@@ -14,13 +14,21 @@ type Config struct {
 	SocketPath  string
 	PidPath     string
 	Version     string
+	Token       string
 }
 
+// machineTokenEnvVar is the environment variable the daemon reads a machine
+// token from so it can authenticate non-interactively, without a user
+// login, when running headless (CI, servers).
+const machineTokenEnvVar = "TORUS_TOKEN"
+
 func NewConfig(arigatoRoot string) (*Config, error) {
 	if len(arigatoRoot) == 0 {
 		arigatoRoot = path.Join(os.Getenv("HOME"), ".arigato")
 	}
 
+	token := os.Getenv(machineTokenEnvVar)
+
 	src, err := os.Stat(arigatoRoot)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, err
@@ -55,6 +63,7 @@ func NewConfig(arigatoRoot string) (*Config, error) {
 		SocketPath:  path.Join(arigatoRoot, "daemon.socket"),
 		PidPath:     path.Join(arigatoRoot, "daemon.pid"),
 		Version:     version,
+		Token:       token,
 	}
 
 	return cfg, nil
@@ -0,0 +1,25 @@
+package errs
+
+import "fmt"
+
+// APIError is returned when the registry responds to a request with a
+// non-2xx status, so callers can tell a server-reported failure (404,
+// 409, auth failure, 500, etc.) apart from a transport error or a
+// cancellation.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// NewAPIError wraps the status code and, when the registry included one,
+// the error message from the response body.
+func NewAPIError(statusCode int, message string) *APIError {
+	return &APIError{StatusCode: statusCode, Message: message}
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("registry returned status %d", e.StatusCode)
+}
@@ -0,0 +1,26 @@
+package errs
+
+import "errors"
+
+// Cancelled is returned when an in-flight request was aborted because its
+// context was cancelled (Ctrl-C, SIGTERM) rather than because of a server
+// or network failure, so callers can print a clean message instead of a
+// stack trace.
+type Cancelled struct {
+	Err error
+}
+
+// NewCancelled wraps the context error that triggered the cancellation.
+func NewCancelled(err error) *Cancelled {
+	return &Cancelled{Err: err}
+}
+
+func (c *Cancelled) Error() string {
+	return "request cancelled"
+}
+
+// IsCancelled reports whether err is (or wraps) a *Cancelled.
+func IsCancelled(err error) bool {
+	var c *Cancelled
+	return errors.As(err, &c)
+}
@@ -0,0 +1,49 @@
+package primitive
+
+import (
+	"time"
+
+	"github.com/manifoldco/torus-cli/identity"
+)
+
+// Machine is an identity representing a non-interactive workload (CI,
+// servers) inside an org. It is assignable to teams the same way a user
+// identity is, but authenticates with a machine token instead of a session.
+type Machine struct {
+	Name      string       `json:"name"`
+	OrgID     *identity.ID `json:"org_id"`
+	State     string       `json:"state"`
+	CreatedBy *identity.ID `json:"created_by"`
+}
+
+// Machine states
+const (
+	MachineActive  = "active"
+	MachineDestroy = "destroyed"
+)
+
+// Valid returns whether or not this machine is valid
+func (m Machine) Valid() bool {
+	return m.Name != "" && m.OrgID != nil
+}
+
+// GetOrgID returns the org ID this machine belongs to
+func (m Machine) GetOrgID() *identity.ID {
+	return m.OrgID
+}
+
+// MachineToken is an opaque bearer credential scoped to a single machine
+// identity, used by the daemon to authenticate to the registry without a
+// user session.
+type MachineToken struct {
+	MachineID *identity.ID `json:"machine_id"`
+	CreatedBy *identity.ID `json:"created_by"`
+	ExpiresAt *time.Time   `json:"expires_at,omitempty"`
+	State     string       `json:"state"`
+}
+
+// MachineToken states
+const (
+	MachineTokenActive  = "active"
+	MachineTokenRevoked = "revoked"
+)
@@ -0,0 +1,60 @@
+// Package trustcache stores public keys a user has chosen to trust
+// directly, without going through the registry, keyed by the org and key
+// ID they were exported under.
+package trustcache
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/manifoldco/torus-cli/identity"
+)
+
+const rootDirName = ".torus/trusted_keys"
+const dirPerm = 0700
+const filePerm = 0600
+
+// root returns ~/.torus/trusted_keys
+func root() string {
+	return path.Join(os.Getenv("HOME"), rootDirName)
+}
+
+// dir returns ~/.torus/trusted_keys/<orgID>, creating it if necessary.
+func dir(orgID *identity.ID) (string, error) {
+	d := path.Join(root(), orgID.String())
+	if err := os.MkdirAll(d, dirPerm); err != nil {
+		return "", err
+	}
+	return d, nil
+}
+
+// Put stores the armored key block for keyID under orgID in the trust
+// cache, overwriting any existing entry.
+func Put(orgID, keyID *identity.ID, block []byte) error {
+	d, err := dir(orgID)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join(d, keyID.String()), block, filePerm)
+}
+
+// Get retrieves the armored key block for keyID under orgID, if one has
+// been pinned locally. It returns (nil, nil) if no such entry exists.
+func Get(orgID, keyID *identity.ID) ([]byte, error) {
+	d, err := dir(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := ioutil.ReadFile(path.Join(d, keyID.String()))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}